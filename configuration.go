@@ -20,6 +20,10 @@ type Configuration struct {
 	ConfigurationValues map[string]string
 	ValidateConfigLine  func(line string) bool
 	FilePath            string
+
+	// rawLines holds the original file content line by line, captured on Reload, so
+	// Save/SaveAs can preserve comments and key order.
+	rawLines []string
 }
 
 // isConfigurationLine internal func for return true if line is correct like key=value
@@ -142,9 +146,11 @@ func (c *Configuration) Reload() error {
 	}
 	defer file.Close()
 
+	c.rawLines = c.rawLines[:0]
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		line := scanner.Text()
+		c.rawLines = append(c.rawLines, line)
 		if c.isConfigurationLine(line) {
 			code := strings.Split(line, "=")[0]
 			value := strings.Split(line, "=")[1:]
@@ -154,6 +160,82 @@ func (c *Configuration) Reload() error {
 	return nil
 }
 
+// Save serializes the current configuration back to c.FilePath. See SaveAs for the write semantics.
+func (c *Configuration) Save() error {
+	return c.SaveAs(c.FilePath)
+}
+
+// SaveAs atomically writes the current configuration to path: it writes to path+".tmp" in the
+// same directory, fsyncs it, then renames it over path, so a crash mid-write never leaves a
+// truncated file behind. Existing lines are kept as-is except ones whose key changed value, so
+// comments and ordering survive; keys added since the last Reload are appended at the end.
+func (c *Configuration) SaveAs(path string) error {
+	if c == nil || c.ConfigurationValues == nil {
+		return errorConfigurationNil
+	}
+
+	written := make(map[string]bool, len(c.ConfigurationValues))
+	lines := make([]string, 0, len(c.rawLines))
+
+	for _, raw := range c.rawLines {
+		code, isKeyValue := splitKeyValueLine(strings.TrimSpace(raw))
+		if !isKeyValue {
+			lines = append(lines, raw)
+			continue
+		}
+
+		key := strings.ToLower(code)
+		value, contains := c.ConfigurationValues[key]
+		if !contains {
+			continue
+		}
+		written[key] = true
+		lines = append(lines, code+"="+value)
+	}
+
+	for key, value := range c.ConfigurationValues {
+		if !written[key] {
+			lines = append(lines, key+"="+value)
+		}
+	}
+
+	return atomicWriteFile(path, []byte(strings.Join(lines, "\n")+"\n"))
+}
+
+// splitKeyValueLine internal func that extracts the key from a trimmed key=value line,
+// returning false for comments and blank lines.
+func splitKeyValueLine(trimmed string) (string, bool) {
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") || !strings.Contains(trimmed, "=") {
+		return "", false
+	}
+	return strings.TrimSpace(strings.SplitN(trimmed, "=", 2)[0]), true
+}
+
+// atomicWriteFile internal func that writes data to path without ever leaving a truncated
+// file in its place: it writes to path+".tmp", fsyncs, then renames over path.
+func atomicWriteFile(path string, data []byte) error {
+	tmpPath := path + ".tmp"
+
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
 // Clear delete all entrys from the map
 func (c *Configuration) Clear() error {
 	if c == nil || c.ConfigurationValues == nil {
@@ -0,0 +1,99 @@
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTempConfigurationFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.conf")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("writing temp config: %v", err)
+	}
+	return path
+}
+
+func TestConfigurationSaveAsPreservesCommentsAndOrder(t *testing.T) {
+	path := writeTempConfigurationFile(t, ""+
+		"# a comment\n"+
+		"foo=bar\n"+
+		"baz=qux\n",
+	)
+
+	c := &Configuration{FilePath: path, ConfigurationValues: make(map[string]string)}
+	if err := c.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	if err := c.UpdateOrAddEntry("foo", "changed"); err != nil {
+		t.Fatalf("UpdateOrAddEntry: %v", err)
+	}
+	if err := c.AddNewKeyValueEntry("new", "value"); err != nil {
+		t.Fatalf("AddNewKeyValueEntry: %v", err)
+	}
+
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading saved file: %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+
+	if lines[0] != "# a comment" {
+		t.Fatalf("expected comment to survive as the first line, got %q", lines[0])
+	}
+	if lines[1] != "foo=changed" {
+		t.Fatalf("expected foo's line to be rewritten in place, got %q", lines[1])
+	}
+	if lines[2] != "baz=qux" {
+		t.Fatalf("expected baz's line untouched, got %q", lines[2])
+	}
+
+	found := false
+	for _, line := range lines[3:] {
+		if line == "new=value" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected new key to be appended, got %v", lines)
+	}
+}
+
+func TestConfigurationSaveAsLeavesOriginalIntactOnWriteFailure(t *testing.T) {
+	path := writeTempConfigurationFile(t, "foo=bar\n")
+
+	c := &Configuration{FilePath: path, ConfigurationValues: make(map[string]string)}
+	if err := c.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if err := c.UpdateOrAddEntry("foo", "corrupted"); err != nil {
+		t.Fatalf("UpdateOrAddEntry: %v", err)
+	}
+
+	// Occupy path+".tmp" with a directory, so the write step of SaveAs fails
+	// partway through instead of completing, simulating a crash mid-write.
+	tmpPath := path + ".tmp"
+	if err := os.Mkdir(tmpPath, 0755); err != nil {
+		t.Fatalf("creating blocking directory: %v", err)
+	}
+	defer os.RemoveAll(tmpPath)
+
+	if err := c.Save(); err == nil {
+		t.Fatal("expected Save to fail while path.tmp is unwritable")
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading original file: %v", err)
+	}
+	if string(content) != "foo=bar\n" {
+		t.Fatalf("original file was corrupted by the failed save: %q", content)
+	}
+}
@@ -1,11 +1,16 @@
 package gonfiguration
 
 import (
-	"bufio"
+	"bytes"
+	"context"
 	"errors"
+	"io"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 var (
@@ -18,65 +23,299 @@ var (
 // Gonfiguration hold info about the session
 type Gonfiguration struct {
 	GonfigurationValues map[string]string
-	ValidateConfigLine  func(line string) bool
-	Path                string
+	// Sections holds the key-value pairs grouped by the part of their key
+	// before the first dot, so keys read under an INI-style [section] header
+	// and dotted keys such as "database.host" or nested JSON/YAML/TOML objects
+	// all land here. Keys without a dot are only in GonfigurationValues.
+	Sections map[string]map[string]string
+	// RunMode, when set, makes every getter try the "mode::key" form of a
+	// key before falling back to the bare key, so a single file can hold
+	// e.g. dev/prod variants side by side.
+	RunMode            string
+	ValidateConfigLine func(line string) bool
+	Path               string
+	// Loader controls how LoadFromPath turns the file at Path into key-value
+	// pairs. It defaults to PropertiesLoader (the original key=value parser);
+	// New picks it automatically from the file extension, or it can be set
+	// explicitly through NewWithLoader.
+	Loader Loader
+	// Strict, when the default PropertiesLoader is in use, makes LoadFromPath
+	// return a *MultiError listing every malformed line, empty key, invalid
+	// UTF-8 sequence and duplicate key (including across !include'd files).
+	// Without it, malformed lines are skipped, empty keys and invalid UTF-8 are
+	// accepted, and the first duplicate key fails the load with a single error.
+	Strict bool
+
+	mutex           sync.RWMutex
+	reloadCallbacks []func(old, new map[string]string, changed []string)
+	lastModTime     time.Time
+	lastSize        int64
+	// rawLines holds the original file content line by line, captured when it was read
+	// through PropertiesLoader, so Save/SaveAs can preserve comments and key order.
+	rawLines []string
 }
 
-// isGonfigurationLine internal func for return true if line is correct like key=value
-func (g *Gonfiguration) isGonfigurationLine(line string) bool {
-	if g.ValidateConfigLine != nil {
-		return g.ValidateConfigLine(line)
+// isSectionHeader internal func for return the section name if line is a [section] header
+func isSectionHeader(line string) (string, bool) {
+	if len(line) < 2 || line[0] != '[' || line[len(line)-1] != ']' {
+		return "", false
 	}
-	return !strings.HasPrefix(line, "#") && line != "" && strings.Contains(line, "=")
+	return strings.TrimSpace(line[1 : len(line)-1]), true
 }
 
-// New return the initialized gonfiguration object
+// unquote internal func that strips a single layer of matching quotes from a value
+func unquote(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' && last == '"') || (first == '\'' && last == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+// scopedKey internal func that builds the flat "section.key" form of a key, lower-cased
+func scopedKey(section, key string) string {
+	if section == "" {
+		return strings.ToLower(key)
+	}
+	return strings.ToLower(section) + "." + strings.ToLower(key)
+}
+
+// lookup internal func that resolves a key, trying "mode::key" before the bare key.
+// Callers must hold g.mutex for reading.
+func (g *Gonfiguration) lookup(key string) (string, bool) {
+	key = strings.ToLower(key)
+	if g.RunMode != "" {
+		if val, contains := g.GonfigurationValues[strings.ToLower(g.RunMode)+"::"+key]; contains {
+			return val, true
+		}
+	}
+	val, contains := g.GonfigurationValues[key]
+	return val, contains
+}
+
+// contains internal func, same as Contains but assumes g.mutex is already held
+func (g *Gonfiguration) contains(key string) bool {
+	_, contains := g.GonfigurationValues[strings.ToLower(key)]
+	return contains
+}
+
+// New return the initialized gonfiguration object, picking a Loader from path's file extension
+// (.json, .yaml/.yml, .toml), defaulting to the key=value PropertiesLoader for anything else
 func New(path string, validateFunction func(line string) bool) (*Gonfiguration, error) {
 	newGonfiguration := new(Gonfiguration)
 	newGonfiguration.GonfigurationValues = make(map[string]string)
 	newGonfiguration.ValidateConfigLine = validateFunction
+	newGonfiguration.Loader = loaderForPath(path)
 	newGonfiguration.Path = path
 	errorLoadingGonfiguration := newGonfiguration.LoadFromPath(path)
 
 	return newGonfiguration, errorLoadingGonfiguration
 }
 
-// LoadFromPath load the file given without clear the map
+// NewWithLoader return the initialized gonfiguration object using the given Loader instead of
+// the extension-based default
+func NewWithLoader(path string, l Loader) (*Gonfiguration, error) {
+	newGonfiguration := new(Gonfiguration)
+	newGonfiguration.GonfigurationValues = make(map[string]string)
+	newGonfiguration.Loader = l
+	newGonfiguration.Path = path
+	errorLoadingGonfiguration := newGonfiguration.LoadFromPath(path)
+
+	return newGonfiguration, errorLoadingGonfiguration
+}
+
+// loaderForPath internal func that picks a Loader from a file extension, or nil for the default
+func loaderForPath(path string) Loader {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return JSONLoader{}
+	case ".yaml", ".yml":
+		return YAMLLoader{}
+	case ".toml":
+		return TOMLLoader{}
+	default:
+		return nil
+	}
+}
+
+// LoadFromPath load the file given, replacing the current map and sections, through g.Loader (or the default
+// PropertiesLoader when it's nil). Keys coming back nested, e.g. "database.host", populate
+// both GonfigurationValues and Sections so GetSection keeps working for every format.
 func (g *Gonfiguration) LoadFromPath(path string) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
 	g.Path = path
 
-	file, err := os.Open(path)
-	if err != nil {
+	loader := g.Loader
+	if loader == nil {
+		properties := PropertiesLoader{ValidateConfigLine: g.ValidateConfigLine, Strict: g.Strict}
+		if path != "" {
+			properties.BasePath = filepath.Dir(path)
+			properties.EntryPath = path
+		}
+		loader = properties
+	}
+
+	var reader io.Reader
+	var rawLines []string
+	if path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return errorCantOpenFile
+		}
+		defer file.Close()
+
+		content, readErr := io.ReadAll(file)
+		if readErr != nil {
+			return readErr
+		}
+		if _, isProperties := loader.(PropertiesLoader); isProperties {
+			rawLines = strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+		}
+		reader = bytes.NewReader(content)
+	} else if _, isEnv := loader.(EnvLoader); !isEnv {
+		// Every bundled Loader but EnvLoader needs a real file to read from; EnvLoader
+		// reads os.Environ() directly and ignores the reader entirely, so it's the only
+		// one that can tolerate an empty path.
 		return errorCantOpenFile
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if g.isGonfigurationLine(line) {
-			key := strings.Split(line, "=")[0]
-			value := strings.Split(line, "=")[1:]
+	values, err := loader.Load(reader)
+	if err != nil {
+		return err
+	}
+
+	// Parse into fresh maps rather than mutating g in place, so a concurrent reader
+	// (GetParamAsString, Len, ...) never observes the config mid-reload: the old maps
+	// stay intact under the read lock until this load has fully succeeded and is ready
+	// to swap in under the same write lock acquisition.
+	freshValues := make(map[string]string, len(values))
+	var freshSections map[string]map[string]string
+	for key, value := range values {
+		key = strings.ToLower(key)
+		if _, exists := freshValues[key]; exists {
+			return errorDuplicatedEntry
+		}
+		setFlatInto(freshValues, &freshSections, key, value)
+	}
 
-			contains, _ := g.Contains(key)
-			if contains {
-				return errorDuplicatedEntry
-			}
-			g.AddNew(strings.ToLower(key), strings.Join(value, "="))
+	// Only commit rawLines once we know the load succeeded, so a failed Reload()
+	// (e.g. a Strict-mode validation error) can't leave rawLines pointing at the new,
+	// invalid file's content while GonfigurationValues still reflects the old one.
+	g.rawLines = rawLines
+	g.GonfigurationValues = freshValues
+	g.Sections = freshSections
+
+	if path != "" {
+		if info, statErr := os.Stat(path); statErr == nil {
+			g.lastModTime = info.ModTime()
+			g.lastSize = info.Size()
 		}
 	}
 	return nil
 }
 
-// Reload clear the map and call LoadFromPath()
+// setFlat internal func that stores a dotted key=value in the flat map and, when the key
+// has a "section.rest" shape, mirrors it into Sections too.
+func (g *Gonfiguration) setFlat(key, value string) {
+	if g.Sections == nil {
+		g.Sections = make(map[string]map[string]string)
+	}
+	setFlatInto(g.GonfigurationValues, &g.Sections, key, value)
+}
+
+// setFlatInto internal func that stores a dotted key=value into values and, when the key
+// has a "section.rest" shape, mirrors it into *sections too, lazily allocating it.
+func setFlatInto(values map[string]string, sections *map[string]map[string]string, key, value string) {
+	values[key] = value
+
+	dot := strings.Index(key, ".")
+	if dot <= 0 {
+		return
+	}
+
+	section, sectionKey := key[:dot], key[dot+1:]
+	if *sections == nil {
+		*sections = make(map[string]map[string]string)
+	}
+	if (*sections)[section] == nil {
+		(*sections)[section] = make(map[string]string)
+	}
+	(*sections)[section][sectionKey] = value
+}
+
+// addToSection internal func that stores key=value scoped to a section, both in the flat
+// map (as "section.key") and in Sections.
+func (g *Gonfiguration) addToSection(section, key, value string) {
+	g.setFlat(scopedKey(section, key), value)
+}
+
+// GetSection return a copy of the key-value pairs read from the given [section], or nil if it doesn't exist
+func (g *Gonfiguration) GetSection(name string) map[string]string {
+	if g == nil {
+		return nil
+	}
+
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	if g.Sections == nil {
+		return nil
+	}
+
+	section, contains := g.Sections[strings.ToLower(name)]
+	if !contains {
+		return nil
+	}
+
+	sectionCopy := make(map[string]string, len(section))
+	for key, value := range section {
+		sectionCopy[key] = value
+	}
+	return sectionCopy
+}
+
+// AddNewInSection add new entry scoped to a section, return error if it exists yet
+func (g *Gonfiguration) AddNewInSection(section, key, value string) error {
+	if g == nil || g.GonfigurationValues == nil {
+		return errorGonfigurationNil
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.contains(scopedKey(section, key)) {
+		return errorDuplicatedEntry
+	}
+	g.addToSection(section, key, value)
+	return nil
+}
+
+// UpdateInSection add or update the value of the key inside a section
+func (g *Gonfiguration) UpdateInSection(section, key, value string) error {
+	if g == nil || g.GonfigurationValues == nil {
+		return errorGonfigurationNil
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.addToSection(section, key, value)
+	return nil
+}
+
+// Reload re-parses g.Path and atomically swaps it in. LoadFromPath takes a single lock for the
+// whole parse-and-swap, so a concurrent reader never observes a transiently empty config the
+// way it would if Reload cleared the map and reloaded it as two separate locked steps.
 func (g *Gonfiguration) Reload() error {
 	if g == nil || g.GonfigurationValues == nil {
 		return errorGonfigurationNil
 	}
 
-	g.Clear()
-	errorLoadFromPath := g.LoadFromPath(g.Path)
-	return errorLoadFromPath
+	return g.LoadFromPath(g.Path)
 }
 
 // GetParamAsString return string value of the key.
@@ -85,7 +324,10 @@ func (g *Gonfiguration) GetParamAsString(key string, def string) (string, error)
 		return "", errorGonfigurationNil
 	}
 
-	val, contains := g.GonfigurationValues[strings.ToLower(key)]
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	val, contains := g.lookup(key)
 	if !contains {
 		return def, errorKeyNotFound
 	}
@@ -98,7 +340,10 @@ func (g *Gonfiguration) GetParamAsStringArray(key, def, sep string) ([]string, e
 		return nil, errorGonfigurationNil
 	}
 
-	val, contains := g.GonfigurationValues[strings.ToLower(key)]
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	val, contains := g.lookup(key)
 	if !contains {
 		return strings.Split(def, sep), errorKeyNotFound
 	}
@@ -111,7 +356,10 @@ func (g *Gonfiguration) GetParamAsInt(key string, def int) (int, error) {
 		return 0, errorGonfigurationNil
 	}
 
-	val, contains := g.GonfigurationValues[strings.ToLower(key)]
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	val, contains := g.lookup(key)
 	if !contains {
 		return def, errorKeyNotFound
 	}
@@ -140,7 +388,10 @@ func (g *Gonfiguration) GetParamAsBool(key string, def bool) (bool, error) {
 		return false, errorGonfigurationNil
 	}
 
-	val, contains := g.GonfigurationValues[strings.ToLower(key)]
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	val, contains := g.lookup(key)
 	if !contains {
 		return def, errorKeyNotFound
 	}
@@ -153,8 +404,10 @@ func (g *Gonfiguration) AddNew(key, value string) error {
 		return errorGonfigurationNil
 	}
 
-	contains, _ := g.Contains(key)
-	if contains {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.contains(key) {
 		return errorDuplicatedEntry
 	}
 	g.GonfigurationValues[strings.ToLower(key)] = value
@@ -166,6 +419,10 @@ func (g *Gonfiguration) Update(key, value string) error {
 	if g == nil || g.GonfigurationValues == nil {
 		return errorGonfigurationNil
 	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
 	g.GonfigurationValues[strings.ToLower(key)] = value
 	return nil
 }
@@ -176,8 +433,10 @@ func (g *Gonfiguration) Delete(key string) error {
 		return errorGonfigurationNil
 	}
 
-	contains, _ := g.Contains(key)
-	if !contains {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if !g.contains(key) {
 		return errorKeyNotFound
 	}
 	delete(g.GonfigurationValues, strings.ToLower(key))
@@ -190,8 +449,10 @@ func (g *Gonfiguration) Contains(key string) (bool, error) {
 		return false, errorGonfigurationNil
 	}
 
-	_, contains := g.GonfigurationValues[strings.ToLower(key)]
-	return contains, nil
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	return g.contains(key), nil
 }
 
 // Clear delete all entrys from the map
@@ -200,9 +461,15 @@ func (g *Gonfiguration) Clear() error {
 		return errorGonfigurationNil
 	}
 
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
 	for key := range g.GonfigurationValues {
 		delete(g.GonfigurationValues, strings.ToLower(key))
 	}
+	for section := range g.Sections {
+		delete(g.Sections, section)
+	}
 	return nil
 }
 
@@ -212,23 +479,142 @@ func (g *Gonfiguration) Len() (int, error) {
 		return 0, errorGonfigurationNil
 	}
 
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
 	return len(g.GonfigurationValues), nil
 }
 
-// Map return the map of the Gonfiguration
+// Map return a copy of the map of the Gonfiguration
 func (g *Gonfiguration) Map() (*map[string]string, error) {
 	if g == nil || g.GonfigurationValues == nil {
 		return nil, errorGonfigurationNil
 	}
 
-	return &g.GonfigurationValues, nil
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	mapCopy := make(map[string]string, len(g.GonfigurationValues))
+	for key, value := range g.GonfigurationValues {
+		mapCopy[key] = value
+	}
+	return &mapCopy, nil
+}
+
+// HasChanged reports whether the file at g.Path has a different mtime or size
+// than it did the last time it was successfully loaded.
+func (g *Gonfiguration) HasChanged() (bool, error) {
+	if g == nil || g.GonfigurationValues == nil {
+		return false, errorGonfigurationNil
+	}
+
+	g.mutex.RLock()
+	path := g.Path
+	lastModTime := g.lastModTime
+	lastSize := g.lastSize
+	g.mutex.RUnlock()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, errorCantOpenFile
+	}
+
+	return !info.ModTime().Equal(lastModTime) || info.Size() != lastSize, nil
+}
+
+// RegisterReloadCallback registers a function to be invoked by Watch after each
+// reload it triggers, with the pre-reload map, the post-reload map, and the
+// list of keys that were added, removed or changed in between.
+func (g *Gonfiguration) RegisterReloadCallback(callback func(old, new map[string]string, changed []string)) {
+	if g == nil || g.GonfigurationValues == nil {
+		return
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.reloadCallbacks = append(g.reloadCallbacks, callback)
+}
+
+// Watch polls g.Path every interval and reloads the configuration whenever its
+// mtime or size changes, dispatching every registered reload callback in
+// order. It blocks until ctx is done, returning ctx.Err(), or until g.Path
+// can't be stat'ed or a reload fails, returning that error.
+func (g *Gonfiguration) Watch(ctx context.Context, interval time.Duration) error {
+	if g == nil || g.GonfigurationValues == nil {
+		return errorGonfigurationNil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			changed, err := g.HasChanged()
+			if err != nil {
+				return err
+			}
+			if !changed {
+				continue
+			}
+
+			g.mutex.RLock()
+			oldValues := make(map[string]string, len(g.GonfigurationValues))
+			for key, value := range g.GonfigurationValues {
+				oldValues[key] = value
+			}
+			g.mutex.RUnlock()
+
+			if err := g.Reload(); err != nil {
+				return err
+			}
+
+			g.mutex.RLock()
+			newValues := make(map[string]string, len(g.GonfigurationValues))
+			for key, value := range g.GonfigurationValues {
+				newValues[key] = value
+			}
+			callbacks := make([]func(old, new map[string]string, changed []string), len(g.reloadCallbacks))
+			copy(callbacks, g.reloadCallbacks)
+			g.mutex.RUnlock()
+
+			changedKeys := diffKeys(oldValues, newValues)
+			for _, callback := range callbacks {
+				callback(oldValues, newValues, changedKeys)
+			}
+		}
+	}
+}
+
+// diffKeys internal func that lists every key added, removed or changed between two snapshots
+func diffKeys(old, new map[string]string) []string {
+	changed := make([]string, 0)
+	for key, newVal := range new {
+		if oldVal, contains := old[key]; !contains || oldVal != newVal {
+			changed = append(changed, key)
+		}
+	}
+	for key := range old {
+		if _, contains := new[key]; !contains {
+			changed = append(changed, key)
+		}
+	}
+	return changed
 }
 
 // Dispose remove all memory used by the object and destroy it
 func (g *Gonfiguration) Dispose() {
 	g.Clear()
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
 	g.GonfigurationValues = nil
+	g.Sections = nil
+	g.reloadCallbacks = nil
 	g.ValidateConfigLine = nil
 	g.Path = ""
-	g = nil
 }
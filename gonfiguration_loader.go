@@ -0,0 +1,360 @@
+package gonfiguration
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// Loader turns raw configuration data into a flat map of dotted, lower-case keys to string
+// values. Nested structure (INI sections, JSON/YAML objects, TOML tables) is expected to come
+// back flattened, e.g. a "host" key under a "database" block becomes "database.host".
+type Loader interface {
+	Load(r io.Reader) (map[string]string, error)
+}
+
+// PropertiesLoader is the default Loader: the original key=value format, with INI-style
+// [section] headers, ';' and '#' comment lines, quoted values and "!include path" directives.
+type PropertiesLoader struct {
+	ValidateConfigLine func(line string) bool
+
+	// Strict makes Load collect every malformed line (with its line number), empty key,
+	// invalid UTF-8 sequence and duplicate key (including across included files) into a
+	// single *MultiError. Without it, malformed lines are skipped, empty keys and invalid
+	// UTF-8 are accepted, and the first duplicate key or failed include aborts the Load.
+	Strict bool
+	// BasePath resolves relative "!include path" directives against it; it defaults to
+	// the current working directory when empty. LoadFromPath sets it to the directory
+	// of the file being loaded.
+	BasePath string
+	// EntryPath, when set, is the path of the file being parsed by this Load call. It is
+	// registered as visited up front so an !include chain that cycles back to the
+	// top-level file is caught too.
+	EntryPath string
+}
+
+// StrictError describes one malformed line found while parsing in Strict mode.
+type StrictError struct {
+	Line    int
+	Message string
+}
+
+func (e *StrictError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// MultiError aggregates every StrictError a Strict Load found, instead of stopping at the first.
+type MultiError struct {
+	Errors []error
+}
+
+func (m *MultiError) Error() string {
+	messages := make([]string, len(m.Errors))
+	for i, err := range m.Errors {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// isLine internal func for return true if line is correct like key=value
+func (l PropertiesLoader) isLine(line string) bool {
+	if l.ValidateConfigLine != nil {
+		return l.ValidateConfigLine(line)
+	}
+	return !strings.HasPrefix(line, "#") && !strings.HasPrefix(line, ";") && line != "" && strings.Contains(line, "=")
+}
+
+// Load implements Loader
+func (l PropertiesLoader) Load(r io.Reader) (map[string]string, error) {
+	basePath := l.BasePath
+	if basePath == "" {
+		basePath = "."
+	}
+
+	visited := make(map[string]bool)
+	if l.EntryPath != "" {
+		if abs, err := filepath.Abs(l.EntryPath); err == nil {
+			visited[abs] = true
+		}
+	}
+	included := make(map[string]bool)
+
+	values := make(map[string]string)
+	var errs []error
+	if err := l.loadReader(r, basePath, values, visited, included, &errs); err != nil {
+		return nil, err
+	}
+
+	if l.Strict && len(errs) > 0 {
+		return nil, &MultiError{Errors: errs}
+	}
+	return values, nil
+}
+
+// loadReader internal func that scans a single reader, following "!include" directives. In
+// strict mode every bad line is appended to errs and scanning continues; otherwise malformed
+// lines are skipped and a duplicate key or failed include returns an error at once
+func (l PropertiesLoader) loadReader(r io.Reader, basePath string, values map[string]string, visited, included map[string]bool, errs *[]error) error {
+	currentSection := ""
+	lineNum := 0
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNum++
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if section, isHeader := isSectionHeader(line); isHeader {
+			currentSection = section
+			continue
+		}
+
+		if includePath, isInclude := parseIncludeLine(line); isInclude {
+			if err := l.loadInclude(includePath, basePath, values, visited, included, errs); err != nil {
+				if l.Strict {
+					*errs = append(*errs, &StrictError{Line: lineNum, Message: err.Error()})
+					continue
+				}
+				return err
+			}
+			continue
+		}
+
+		if l.ValidateConfigLine != nil && !l.ValidateConfigLine(line) {
+			continue
+		}
+
+		if !strings.Contains(line, "=") {
+			if l.Strict {
+				*errs = append(*errs, &StrictError{Line: lineNum, Message: fmt.Sprintf("malformed line: %q", raw)})
+			}
+			continue
+		}
+
+		key := strings.TrimSpace(strings.Split(line, "=")[0])
+		rest := strings.Split(line, "=")[1:]
+		rawValue := unquote(strings.TrimSpace(strings.Join(rest, "=")))
+
+		if l.Strict {
+			if key == "" {
+				*errs = append(*errs, &StrictError{Line: lineNum, Message: "empty key"})
+				continue
+			}
+			if !utf8.ValidString(raw) {
+				*errs = append(*errs, &StrictError{Line: lineNum, Message: "invalid UTF-8"})
+				continue
+			}
+		}
+
+		scoped := scopedKey(currentSection, key)
+		if _, exists := values[scoped]; exists {
+			if l.Strict {
+				*errs = append(*errs, &StrictError{Line: lineNum, Message: fmt.Sprintf("duplicate key %q", scoped)})
+				continue
+			}
+			return errorDuplicatedEntry
+		}
+		values[scoped] = rawValue
+	}
+	return nil
+}
+
+// loadInclude internal func that resolves and recursively loads an "!include path" directive.
+// visited tracks only the active ancestor path (popped on return) and catches a genuine cycle;
+// included accumulates for the whole Load call and catches a diamond include - two different
+// files both !include'ing the same shared fragment - which is skipped the second time rather
+// than re-merged into values, so it neither mistaken for a cycle nor trips the duplicate-key check.
+func (l PropertiesLoader) loadInclude(includePath, basePath string, values map[string]string, visited, included map[string]bool, errs *[]error) error {
+	if !filepath.IsAbs(includePath) {
+		includePath = filepath.Join(basePath, includePath)
+	}
+	absPath, err := filepath.Abs(includePath)
+	if err != nil {
+		return err
+	}
+	if visited[absPath] {
+		return fmt.Errorf("include cycle detected at %s", absPath)
+	}
+	if included[absPath] {
+		return nil
+	}
+	visited[absPath] = true
+	included[absPath] = true
+	defer delete(visited, absPath)
+
+	file, err := os.Open(absPath)
+	if err != nil {
+		return errorCantOpenFile
+	}
+	defer file.Close()
+
+	return l.loadReader(file, filepath.Dir(absPath), values, visited, included, errs)
+}
+
+// parseIncludeLine internal func that recognises a "!include path/to/file" directive
+func parseIncludeLine(line string) (string, bool) {
+	const prefix = "!include "
+	if !strings.HasPrefix(line, prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(line, prefix)), true
+}
+
+// JSONLoader reads a JSON object, flattening nested objects to dotted lower-case keys
+// and joining arrays with a comma, the same separator a gonf tag's sep option defaults to.
+type JSONLoader struct{}
+
+// Load implements Loader
+func (JSONLoader) Load(r io.Reader) (map[string]string, error) {
+	var raw interface{}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	flatten(raw, "", values)
+	return values, nil
+}
+
+// YAMLLoader reads a minimal, dependency-free subset of YAML: indentation-nested mappings
+// of scalar values. Lists, anchors and multi-document files are not supported.
+type YAMLLoader struct{}
+
+// Load implements Loader
+func (YAMLLoader) Load(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	var path []string
+	var indents []int
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		for len(indents) > 0 && indent <= indents[len(indents)-1] {
+			indents = indents[:len(indents)-1]
+			path = path[:len(path)-1]
+		}
+
+		colon := strings.Index(trimmed, ":")
+		if colon < 0 {
+			continue
+		}
+
+		key := strings.ToLower(strings.TrimSpace(trimmed[:colon]))
+		value := unquote(strings.TrimSpace(trimmed[colon+1:]))
+		fullKey := strings.Join(append(append([]string{}, path...), key), ".")
+
+		if value == "" {
+			path = append(path, key)
+			indents = append(indents, indent)
+			continue
+		}
+		values[fullKey] = value
+	}
+	return values, nil
+}
+
+// TOMLLoader reads a minimal, dependency-free subset of TOML: [table] headers and
+// key = value lines with quoted or bare values. Arrays and inline tables are not supported.
+type TOMLLoader struct{}
+
+// Load implements Loader
+func (TOMLLoader) Load(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	currentTable := ""
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if section, isHeader := isSectionHeader(line); isHeader {
+			currentTable = section
+			continue
+		}
+
+		if !strings.Contains(line, "=") {
+			continue
+		}
+
+		key := strings.TrimSpace(strings.Split(line, "=")[0])
+		rest := strings.Split(line, "=")[1:]
+		rawValue := unquote(strings.TrimSpace(strings.Join(rest, "=")))
+
+		values[scopedKey(currentTable, key)] = rawValue
+	}
+	return values, nil
+}
+
+// EnvLoader reads the process environment. When Prefix is set, only variables starting with
+// it are kept, with the prefix stripped before the key is lower-cased.
+type EnvLoader struct {
+	Prefix string
+}
+
+// Load implements Loader. The reader is ignored: EnvLoader reads os.Environ() directly.
+func (l EnvLoader) Load(_ io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, entry := range os.Environ() {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		key := parts[0]
+		if l.Prefix != "" {
+			if !strings.HasPrefix(key, l.Prefix) {
+				continue
+			}
+			key = strings.TrimPrefix(key, l.Prefix)
+		}
+		values[strings.ToLower(key)] = parts[1]
+	}
+	return values, nil
+}
+
+// flatten internal func that walks a decoded JSON value, writing every scalar it finds
+// into out under its dotted, lower-case key path.
+func flatten(value interface{}, prefix string, out map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			flatten(nested, dottedKey(prefix, key), out)
+		}
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = fmt.Sprintf("%v", item)
+		}
+		out[prefix] = strings.Join(parts, ",")
+	case nil:
+		out[prefix] = ""
+	default:
+		out[prefix] = fmt.Sprintf("%v", v)
+	}
+}
+
+// dottedKey internal func that appends key to prefix, lower-cased, separated by a dot
+func dottedKey(prefix, key string) string {
+	key = strings.ToLower(key)
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,117 @@
+package gonfiguration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTempFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("writing temp file: %v", err)
+	}
+	return path
+}
+
+func TestJSONLoaderFlattensNestedObjects(t *testing.T) {
+	path := writeTempFile(t, "config.json", `{
+		"Name": "svc",
+		"Database": {"Host": "localhost", "Port": 5432},
+		"Tags": ["a", "b"]
+	}`)
+
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if val, _ := g.GetParamAsString("name", ""); val != "svc" {
+		t.Fatalf("expected name=svc, got %q", val)
+	}
+	if val, _ := g.GetParamAsString("database.host", ""); val != "localhost" {
+		t.Fatalf("expected database.host=localhost, got %q", val)
+	}
+	if val, _ := g.GetParamAsString("tags", ""); val != "a,b" {
+		t.Fatalf("expected tags to join with a comma, got %q", val)
+	}
+}
+
+func TestYAMLLoaderFlattensIndentedMappings(t *testing.T) {
+	path := writeTempFile(t, "config.yaml", ""+
+		"name: svc\n"+
+		"database:\n"+
+		"  host: localhost\n"+
+		"  port: 5432\n",
+	)
+
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if val, _ := g.GetParamAsString("name", ""); val != "svc" {
+		t.Fatalf("expected name=svc, got %q", val)
+	}
+	if val, _ := g.GetParamAsString("database.host", ""); val != "localhost" {
+		t.Fatalf("expected database.host=localhost, got %q", val)
+	}
+	if val, _ := g.GetParamAsString("database.port", ""); val != "5432" {
+		t.Fatalf("expected database.port=5432, got %q", val)
+	}
+}
+
+func TestTOMLLoaderReadsTableHeaders(t *testing.T) {
+	path := writeTempFile(t, "config.toml", ""+
+		"name = \"svc\"\n"+
+		"[database]\n"+
+		"host = \"localhost\"\n"+
+		"port = \"5432\"\n",
+	)
+
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if val, _ := g.GetParamAsString("name", ""); val != "svc" {
+		t.Fatalf("expected name=svc, got %q", val)
+	}
+	if val, _ := g.GetParamAsString("database.host", ""); val != "localhost" {
+		t.Fatalf("expected database.host=localhost, got %q", val)
+	}
+}
+
+func TestEnvLoaderFiltersByPrefixAndStripsIt(t *testing.T) {
+	t.Setenv("GONF_HOST", "localhost")
+	t.Setenv("GONF_PORT", "5432")
+	t.Setenv("OTHER_VAR", "ignored")
+
+	g, err := NewWithLoader("", EnvLoader{Prefix: "GONF_"})
+	if err != nil {
+		t.Fatalf("NewWithLoader: %v", err)
+	}
+
+	if val, _ := g.GetParamAsString("host", ""); val != "localhost" {
+		t.Fatalf("expected host=localhost, got %q", val)
+	}
+	if val, _ := g.GetParamAsString("port", ""); val != "5432" {
+		t.Fatalf("expected port=5432, got %q", val)
+	}
+	if contains, _ := g.Contains("other_var"); contains {
+		t.Fatalf("expected unprefixed vars to be filtered out")
+	}
+}
+
+func TestNewAutoDetectsLoaderFromExtension(t *testing.T) {
+	path := writeTempFile(t, "config.json", `{"name": "svc"}`)
+
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, ok := g.Loader.(JSONLoader); !ok {
+		t.Fatalf("expected New to pick JSONLoader for a .json path, got %T", g.Loader)
+	}
+}
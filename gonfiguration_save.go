@@ -0,0 +1,114 @@
+package gonfiguration
+
+import (
+	"os"
+	"strings"
+)
+
+// Save serializes the current configuration back to g.Path. See SaveAs for the write semantics.
+func (g *Gonfiguration) Save() error {
+	return g.SaveAs(g.Path)
+}
+
+// SaveAs atomically writes the current configuration to path: it writes to path+".tmp" in the
+// same directory, fsyncs it, then renames it over path, so a crash mid-write never leaves a
+// truncated file behind. When the file was originally read through PropertiesLoader, existing
+// lines are kept as-is except ones whose key changed value, so comments and ordering survive;
+// keys added since the load are appended at the end. Any other Loader falls back to a plain
+// key=value dump, one line per entry.
+func (g *Gonfiguration) SaveAs(path string) error {
+	g.mutex.RLock()
+	lines := g.renderLines()
+	g.mutex.RUnlock()
+
+	return atomicWriteFile(path, []byte(strings.Join(lines, "\n")+"\n"))
+}
+
+// renderLines internal func, assumes g.mutex is already held for reading
+func (g *Gonfiguration) renderLines() []string {
+	if g.rawLines == nil {
+		return dumpLines(g.GonfigurationValues)
+	}
+
+	written := make(map[string]bool, len(g.GonfigurationValues))
+	lines := make([]string, 0, len(g.rawLines))
+	currentSection := ""
+
+	for _, raw := range g.rawLines {
+		trimmed := strings.TrimSpace(raw)
+
+		if section, isHeader := isSectionHeader(trimmed); isHeader {
+			currentSection = section
+			lines = append(lines, raw)
+			continue
+		}
+
+		key, isKeyValue := splitKeyValueLine(trimmed)
+		if !isKeyValue {
+			lines = append(lines, raw)
+			continue
+		}
+
+		scoped := scopedKey(currentSection, key)
+		value, contains := g.GonfigurationValues[scoped]
+		if !contains {
+			continue
+		}
+		written[scoped] = true
+		if value == unquote(strings.TrimSpace(strings.SplitN(trimmed, "=", 2)[1])) {
+			lines = append(lines, raw)
+			continue
+		}
+		lines = append(lines, key+"="+value)
+	}
+
+	for key, value := range g.GonfigurationValues {
+		if !written[key] {
+			lines = append(lines, key+"="+value)
+		}
+	}
+	return lines
+}
+
+// splitKeyValueLine internal func that extracts the key from a trimmed key=value line,
+// returning false for comments, section headers and blank lines.
+func splitKeyValueLine(trimmed string) (string, bool) {
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") || !strings.Contains(trimmed, "=") {
+		return "", false
+	}
+	return strings.TrimSpace(strings.SplitN(trimmed, "=", 2)[0]), true
+}
+
+// dumpLines internal func that renders a flat map as key=value lines, one entry per line
+func dumpLines(values map[string]string) []string {
+	lines := make([]string, 0, len(values))
+	for key, value := range values {
+		lines = append(lines, key+"="+value)
+	}
+	return lines
+}
+
+// atomicWriteFile internal func that writes data to path without ever leaving a truncated
+// file in its place: it writes to path+".tmp", fsyncs, then renames over path.
+func atomicWriteFile(path string, data []byte) error {
+	tmpPath := path + ".tmp"
+
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,106 @@
+package gonfiguration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveAsPreservesCommentsAndOrder(t *testing.T) {
+	path := writeTempConfig(t, ""+
+		"# a comment\n"+
+		"foo=bar\n"+
+		"baz=qux\n",
+	)
+
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if err := g.Update("foo", "changed"); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := g.AddNew("new", "value"); err != nil {
+		t.Fatalf("AddNew: %v", err)
+	}
+
+	if err := g.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading saved file: %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+
+	if lines[0] != "# a comment" {
+		t.Fatalf("expected comment to survive as the first line, got %q", lines[0])
+	}
+	if lines[1] != "foo=changed" {
+		t.Fatalf("expected foo's line to be rewritten in place, got %q", lines[1])
+	}
+	if lines[2] != "baz=qux" {
+		t.Fatalf("expected baz's line untouched, got %q", lines[2])
+	}
+
+	found := false
+	for _, line := range lines[3:] {
+		if line == "new=value" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected new key to be appended, got %v", lines)
+	}
+}
+
+func TestSaveAsLeavesOriginalIntactOnWriteFailure(t *testing.T) {
+	path := writeTempConfig(t, "foo=bar\n")
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := g.Update("foo", "corrupted"); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	// Occupy path+".tmp" with a directory, so the write step of SaveAs fails
+	// partway through instead of completing, simulating a crash mid-write.
+	tmpPath := path + ".tmp"
+	if err := os.Mkdir(tmpPath, 0755); err != nil {
+		t.Fatalf("creating blocking directory: %v", err)
+	}
+	defer os.RemoveAll(tmpPath)
+
+	if err := g.Save(); err == nil {
+		t.Fatal("expected Save to fail while path.tmp is unwritable")
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading original file: %v", err)
+	}
+	if string(content) != "foo=bar\n" {
+		t.Fatalf("original file was corrupted by the failed save: %q", content)
+	}
+}
+
+func TestSaveAsWithoutRawLinesDumpsFlatMap(t *testing.T) {
+	g := &Gonfiguration{GonfigurationValues: map[string]string{"foo": "bar"}}
+	path := filepath.Join(t.TempDir(), "out.conf")
+
+	if err := g.SaveAs(path); err != nil {
+		t.Fatalf("SaveAs: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading saved file: %v", err)
+	}
+	if strings.TrimSpace(string(content)) != "foo=bar" {
+		t.Fatalf("unexpected dump content: %q", content)
+	}
+}
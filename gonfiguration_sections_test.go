@@ -0,0 +1,130 @@
+package gonfiguration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTempConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.conf")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("writing temp config: %v", err)
+	}
+	return path
+}
+
+func TestLoadFromPathSectionMerging(t *testing.T) {
+	path := writeTempConfig(t, ""+
+		"global=1\n"+
+		"[database]\n"+
+		"host=localhost\n"+
+		"port=5432\n"+
+		"[cache]\n"+
+		"host=redis\n",
+	)
+
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if val, _ := g.GetParamAsString("global", ""); val != "1" {
+		t.Fatalf("expected global=1, got %q", val)
+	}
+	if val, _ := g.GetParamAsString("database.host", ""); val != "localhost" {
+		t.Fatalf("expected database.host=localhost, got %q", val)
+	}
+
+	database := g.GetSection("database")
+	if database["host"] != "localhost" || database["port"] != "5432" {
+		t.Fatalf("unexpected database section: %#v", database)
+	}
+
+	cache := g.GetSection("cache")
+	if cache["host"] != "redis" {
+		t.Fatalf("unexpected cache section: %#v", cache)
+	}
+
+	if len(database) != 2 || len(cache) != 1 {
+		t.Fatalf("sections bled into each other: database=%#v cache=%#v", database, cache)
+	}
+}
+
+func TestLoadFromPathCommentCharacters(t *testing.T) {
+	path := writeTempConfig(t, ""+
+		"# hash=comment\n"+
+		"; semicolon=comment\n"+
+		"kept=yes\n",
+	)
+
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if length, _ := g.Len(); length != 1 {
+		t.Fatalf("expected only 1 key to survive comments, got %d", length)
+	}
+	if val, _ := g.GetParamAsString("kept", ""); val != "yes" {
+		t.Fatalf("expected kept=yes, got %q", val)
+	}
+}
+
+func TestLoadFromPathQuotedValues(t *testing.T) {
+	path := writeTempConfig(t, ""+
+		`double="hello world"`+"\n"+
+		`single='hi there'`+"\n"+
+		`bare=plain`+"\n",
+	)
+
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	cases := map[string]string{
+		"double": "hello world",
+		"single": "hi there",
+		"bare":   "plain",
+	}
+	for key, want := range cases {
+		if got, _ := g.GetParamAsString(key, ""); got != want {
+			t.Fatalf("key %q: got %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestGetSectionMissingReturnsNil(t *testing.T) {
+	path := writeTempConfig(t, "[web]\nport=8080\n")
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if section := g.GetSection("does-not-exist"); section != nil {
+		t.Fatalf("expected nil for missing section, got %#v", section)
+	}
+}
+
+func TestRunModeOverlay(t *testing.T) {
+	path := writeTempConfig(t, ""+
+		"port=8080\n"+
+		"dev::port=9090\n",
+	)
+
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if val, _ := g.GetParamAsString("port", ""); val != "8080" {
+		t.Fatalf("expected bare key with no RunMode, got %q", val)
+	}
+
+	g.RunMode = "dev"
+	if val, _ := g.GetParamAsString("port", ""); val != "9090" {
+		t.Fatalf("expected dev::port to win once RunMode is set, got %q", val)
+	}
+}
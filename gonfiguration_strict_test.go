@@ -0,0 +1,105 @@
+package gonfiguration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStrictModeAggregatesMalformedLines(t *testing.T) {
+	path := writeTempConfig(t, ""+
+		"good=1\n"+
+		"=noKey\n"+
+		"justAKey\n"+
+		"good=1\n",
+	)
+
+	g := &Gonfiguration{GonfigurationValues: make(map[string]string), Strict: true}
+	err := g.LoadFromPath(path)
+	if err == nil {
+		t.Fatal("expected Strict mode to return an error for the malformed lines")
+	}
+
+	multi, ok := err.(*MultiError)
+	if !ok {
+		t.Fatalf("expected a *MultiError, got %T: %v", err, err)
+	}
+	if len(multi.Errors) != 3 {
+		t.Fatalf("expected 3 aggregated errors (empty key, malformed line, duplicate key), got %d: %v", len(multi.Errors), multi.Errors)
+	}
+}
+
+func TestIncludeLoadsReferencedFile(t *testing.T) {
+	dir := t.TempDir()
+	includedPath := filepath.Join(dir, "included.conf")
+	if err := os.WriteFile(includedPath, []byte("host=localhost\n"), 0644); err != nil {
+		t.Fatalf("writing included file: %v", err)
+	}
+
+	mainPath := filepath.Join(dir, "main.conf")
+	if err := os.WriteFile(mainPath, []byte("!include included.conf\nport=8080\n"), 0644); err != nil {
+		t.Fatalf("writing main file: %v", err)
+	}
+
+	g, err := New(mainPath, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if val, _ := g.GetParamAsString("host", ""); val != "localhost" {
+		t.Fatalf("expected host=localhost from the included file, got %q", val)
+	}
+	if val, _ := g.GetParamAsString("port", ""); val != "8080" {
+		t.Fatalf("expected port=8080 from the main file, got %q", val)
+	}
+}
+
+func TestIncludeDetectsActualCycle(t *testing.T) {
+	dir := t.TempDir()
+	aPath := filepath.Join(dir, "a.conf")
+	bPath := filepath.Join(dir, "b.conf")
+	if err := os.WriteFile(aPath, []byte("!include b.conf\n"), 0644); err != nil {
+		t.Fatalf("writing a.conf: %v", err)
+	}
+	if err := os.WriteFile(bPath, []byte("!include a.conf\n"), 0644); err != nil {
+		t.Fatalf("writing b.conf: %v", err)
+	}
+
+	if _, err := New(aPath, nil); err == nil {
+		t.Fatal("expected a genuine include cycle to be reported")
+	}
+}
+
+func TestIncludeAllowsDiamondSharedFile(t *testing.T) {
+	dir := t.TempDir()
+	commonPath := filepath.Join(dir, "common.conf")
+	aPath := filepath.Join(dir, "a.conf")
+	bPath := filepath.Join(dir, "b.conf")
+	mainPath := filepath.Join(dir, "main.conf")
+
+	if err := os.WriteFile(commonPath, []byte("shared=1\n"), 0644); err != nil {
+		t.Fatalf("writing common.conf: %v", err)
+	}
+	if err := os.WriteFile(aPath, []byte("!include common.conf\na_only=1\n"), 0644); err != nil {
+		t.Fatalf("writing a.conf: %v", err)
+	}
+	if err := os.WriteFile(bPath, []byte("!include common.conf\nb_only=1\n"), 0644); err != nil {
+		t.Fatalf("writing b.conf: %v", err)
+	}
+	if err := os.WriteFile(mainPath, []byte("!include a.conf\n!include b.conf\n"), 0644); err != nil {
+		t.Fatalf("writing main.conf: %v", err)
+	}
+
+	g, err := New(mainPath, nil)
+	if err != nil {
+		t.Fatalf("New: %v (diamond include wrongly reported as a cycle)", err)
+	}
+	if val, _ := g.GetParamAsString("shared", ""); val != "1" {
+		t.Fatalf("expected shared=1 from common.conf, got %q", val)
+	}
+	if val, _ := g.GetParamAsString("a_only", ""); val != "1" {
+		t.Fatalf("expected a_only=1, got %q", val)
+	}
+	if val, _ := g.GetParamAsString("b_only", ""); val != "1" {
+		t.Fatalf("expected b_only=1, got %q", val)
+	}
+}
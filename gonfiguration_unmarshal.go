@@ -0,0 +1,161 @@
+package gonfiguration
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errorUnmarshalTarget = errors.New("gonfiguration: Unmarshal requires a non-nil pointer to struct")
+
+// gonfTag holds the parsed pieces of a `gonf:"key,default=...,required,sep=..."` struct tag
+type gonfTag struct {
+	key       string
+	def       string
+	hasDef    bool
+	required  bool
+	separator string
+}
+
+// parseGonfTag internal func that splits a gonf struct tag into its key and options
+func parseGonfTag(tag string) gonfTag {
+	parts := strings.Split(tag, ",")
+	parsed := gonfTag{key: parts[0], separator: ","}
+
+	for _, opt := range parts[1:] {
+		switch {
+		case opt == "required":
+			parsed.required = true
+		case strings.HasPrefix(opt, "default="):
+			parsed.def = strings.TrimPrefix(opt, "default=")
+			parsed.hasDef = true
+		case strings.HasPrefix(opt, "sep="):
+			parsed.separator = strings.TrimPrefix(opt, "sep=")
+		}
+	}
+	return parsed
+}
+
+// Unmarshal populates the fields of out, a pointer to struct, from the configuration map.
+// Fields are matched through their `gonf:"key,default=...,required"` tag; untagged fields
+// are left untouched. Nested structs are addressed with dotted key paths, the outer
+// field's key becoming the prefix for its own fields. Every missing required key and
+// every type-conversion failure is collected and returned together as a single error.
+func (g *Gonfiguration) Unmarshal(out interface{}) error {
+	if g == nil || g.GonfigurationValues == nil {
+		return errorGonfigurationNil
+	}
+
+	value := reflect.ValueOf(out)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return errorUnmarshalTarget
+	}
+
+	var errs []string
+	g.unmarshalStruct(value.Elem(), "", &errs)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("gonfiguration: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// unmarshalStruct internal func that walks structValue's fields, appending every failure to errs
+func (g *Gonfiguration) unmarshalStruct(structValue reflect.Value, prefix string, errs *[]string) {
+	structType := structValue.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldValue := structValue.Field(i)
+
+		tagRaw, ok := field.Tag.Lookup("gonf")
+		if !ok {
+			continue
+		}
+		if field.PkgPath != "" {
+			// Unexported field: fieldValue.Interface() would panic, and Set would too, so
+			// skip it the way encoding/json does rather than crash on a tagged-but-unexported field.
+			continue
+		}
+		tag := parseGonfTag(tagRaw)
+		key := tag.key
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if fieldValue.Kind() == reflect.Struct {
+			g.unmarshalStruct(fieldValue, key, errs)
+			continue
+		}
+
+		g.mutex.RLock()
+		raw, contains := g.lookup(key)
+		g.mutex.RUnlock()
+
+		if !contains {
+			if tag.hasDef {
+				raw, contains = tag.def, true
+			} else if tag.required {
+				*errs = append(*errs, fmt.Sprintf("missing required key %q", key))
+				continue
+			} else {
+				continue
+			}
+		}
+
+		if err := setFieldValue(fieldValue, raw, tag.separator); err != nil {
+			*errs = append(*errs, fmt.Sprintf("key %q: %s", key, err))
+		}
+	}
+}
+
+// setFieldValue internal func that converts raw into fieldValue's type and assigns it
+func setFieldValue(fieldValue reflect.Value, raw, separator string) error {
+	if _, ok := fieldValue.Interface().(time.Duration); ok {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		fieldValue.Set(reflect.ValueOf(parsed))
+		return nil
+	}
+
+	switch fieldValue.Kind() {
+	case reflect.String:
+		fieldValue.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		intVal, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		fieldValue.SetInt(intVal)
+	case reflect.Bool:
+		boolVal, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fieldValue.SetBool(boolVal)
+	case reflect.Float32, reflect.Float64:
+		floatVal, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		fieldValue.SetFloat(floatVal)
+	case reflect.Slice:
+		if fieldValue.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice element type %s", fieldValue.Type().Elem())
+		}
+		parts := strings.Split(raw, separator)
+		slice := reflect.MakeSlice(fieldValue.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			slice.Index(i).SetString(part)
+		}
+		fieldValue.Set(slice)
+	default:
+		return fmt.Errorf("unsupported field type %s", fieldValue.Kind())
+	}
+	return nil
+}
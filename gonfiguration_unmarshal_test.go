@@ -0,0 +1,143 @@
+package gonfiguration
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnmarshalPopulatesFields(t *testing.T) {
+	path := writeTempConfig(t, ""+
+		"name=svc\n"+
+		"port=8080\n"+
+		"debug=true\n"+
+		"timeout=1500ms\n"+
+		"tags=a,b,c\n"+
+		"database.host=localhost\n"+
+		"database.port=5432\n",
+	)
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	type database struct {
+		Host string `gonf:"host"`
+		Port int    `gonf:"port"`
+	}
+	type target struct {
+		Name     string        `gonf:"name"`
+		Port     int           `gonf:"port"`
+		Debug    bool          `gonf:"debug"`
+		Timeout  time.Duration `gonf:"timeout"`
+		Tags     []string      `gonf:"tags"`
+		Untagged string
+		Database database `gonf:"database"`
+	}
+
+	var out target
+	if err := g.Unmarshal(&out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if out.Name != "svc" || out.Port != 8080 || !out.Debug {
+		t.Fatalf("unexpected scalar fields: %#v", out)
+	}
+	if out.Timeout != 1500*time.Millisecond {
+		t.Fatalf("unexpected timeout: %v", out.Timeout)
+	}
+	if strings.Join(out.Tags, ",") != "a,b,c" {
+		t.Fatalf("unexpected tags: %#v", out.Tags)
+	}
+	if out.Database.Host != "localhost" || out.Database.Port != 5432 {
+		t.Fatalf("unexpected nested struct: %#v", out.Database)
+	}
+}
+
+func TestUnmarshalUsesDefaultAndAggregatesMissingRequired(t *testing.T) {
+	path := writeTempConfig(t, "name=svc\n")
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	type target struct {
+		Name string `gonf:"name"`
+		Port int    `gonf:"port,default=9090"`
+		Host string `gonf:"host,required"`
+		User string `gonf:"user,required"`
+	}
+
+	var out target
+	err = g.Unmarshal(&out)
+	if err == nil {
+		t.Fatal("expected an error for the missing required keys")
+	}
+	if !strings.Contains(err.Error(), "host") || !strings.Contains(err.Error(), "user") {
+		t.Fatalf("expected both missing keys listed in one error, got %v", err)
+	}
+	if out.Port != 9090 {
+		t.Fatalf("expected default to apply despite the later error, got %d", out.Port)
+	}
+}
+
+func TestUnmarshalAggregatesConversionFailures(t *testing.T) {
+	path := writeTempConfig(t, ""+
+		"port=not-a-number\n"+
+		"debug=not-a-bool\n",
+	)
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	type target struct {
+		Port  int  `gonf:"port"`
+		Debug bool `gonf:"debug"`
+	}
+
+	var out target
+	err = g.Unmarshal(&out)
+	if err == nil {
+		t.Fatal("expected an error for the unparsable fields")
+	}
+	if !strings.Contains(err.Error(), "port") || !strings.Contains(err.Error(), "debug") {
+		t.Fatalf("expected both conversion failures listed in one error, got %v", err)
+	}
+}
+
+func TestUnmarshalSkipsUnexportedTaggedFields(t *testing.T) {
+	path := writeTempConfig(t, "name=svc\n")
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	type target struct {
+		name string `gonf:"name"`
+	}
+
+	var out target
+	if err := g.Unmarshal(&out); err != nil {
+		t.Fatalf("expected Unmarshal not to panic or error on an unexported field, got %v", err)
+	}
+	if out.name != "" {
+		t.Fatalf("expected unexported field to be left untouched, got %q", out.name)
+	}
+}
+
+func TestUnmarshalRejectsNonPointer(t *testing.T) {
+	path := writeTempConfig(t, "name=svc\n")
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	type target struct {
+		Name string `gonf:"name"`
+	}
+
+	if err := g.Unmarshal(target{}); err != errorUnmarshalTarget {
+		t.Fatalf("expected errorUnmarshalTarget, got %v", err)
+	}
+}
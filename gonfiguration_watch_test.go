@@ -0,0 +1,168 @@
+package gonfiguration
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestHasChangedDetectsRewrite(t *testing.T) {
+	path := writeTempConfig(t, "foo=bar\n")
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if changed, err := g.HasChanged(); err != nil || changed {
+		t.Fatalf("expected no change right after load, got changed=%v err=%v", changed, err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("foo=baz\nextra=1\n"), 0644); err != nil {
+		t.Fatalf("rewriting temp file: %v", err)
+	}
+
+	changed, err := g.HasChanged()
+	if err != nil {
+		t.Fatalf("HasChanged: %v", err)
+	}
+	if !changed {
+		t.Fatalf("expected HasChanged to report true after rewrite")
+	}
+}
+
+func TestWatchReloadsAndDispatchesCallback(t *testing.T) {
+	path := writeTempConfig(t, "foo=bar\n")
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	type callbackArgs struct {
+		old, new map[string]string
+		changed  []string
+	}
+	received := make(chan callbackArgs, 1)
+	g.RegisterReloadCallback(func(old, new map[string]string, changed []string) {
+		received <- callbackArgs{old, new, changed}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- g.Watch(ctx, 5*time.Millisecond) }()
+
+	time.Sleep(20 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("foo=baz\nextra=1\n"), 0644); err != nil {
+		t.Fatalf("rewriting temp file: %v", err)
+	}
+
+	select {
+	case args := <-received:
+		if args.new["foo"] != "baz" || args.new["extra"] != "1" {
+			t.Fatalf("unexpected new values: %#v", args.new)
+		}
+		if args.old["foo"] != "bar" {
+			t.Fatalf("unexpected old values: %#v", args.old)
+		}
+		if len(args.changed) == 0 {
+			t.Fatalf("expected at least one changed key")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for reload callback")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Fatalf("expected Watch to return context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Watch did not return after ctx was cancelled")
+	}
+}
+
+func TestReloadNeverExposesAnEmptyMap(t *testing.T) {
+	path := writeTempConfig(t, "foo=bar\n")
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			if err := g.Reload(); err != nil {
+				t.Errorf("Reload: %v", err)
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		if length, _ := g.Len(); length == 0 {
+			t.Fatalf("reader observed an empty map mid-reload")
+		}
+	}
+	<-done
+}
+
+func TestNilReceiverMethodsDontPanic(t *testing.T) {
+	var g *Gonfiguration
+
+	if _, err := g.HasChanged(); err != errorGonfigurationNil {
+		t.Fatalf("expected errorGonfigurationNil, got %v", err)
+	}
+
+	g.RegisterReloadCallback(func(old, new map[string]string, changed []string) {})
+
+	if err := g.Watch(context.Background(), time.Millisecond); err != errorGonfigurationNil {
+		t.Fatalf("expected errorGonfigurationNil, got %v", err)
+	}
+}
+
+func TestMapReturnsADefensiveCopy(t *testing.T) {
+	path := writeTempConfig(t, "foo=bar\n")
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	m, err := g.Map()
+	if err != nil {
+		t.Fatalf("Map: %v", err)
+	}
+	(*m)["foo"] = "mutated-through-the-copy"
+
+	if val, _ := g.GetParamAsString("foo", ""); val != "bar" {
+		t.Fatalf("expected mutating the returned map not to affect g, got %q", val)
+	}
+}
+
+func TestConcurrentAccessIsRaceFree(t *testing.T) {
+	path := writeTempConfig(t, "foo=bar\n")
+	g, err := New(path, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			g.Update("foo", "bar")
+			g.GetParamAsString("foo", "")
+			g.Contains("foo")
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		g.GetSection("missing")
+		g.Len()
+	}
+	<-done
+}